@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// adminToken gates the admin endpoints and is loaded once at startup.
+var adminToken string
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 200
+	rssMaxItems     = 50
+)
+
+// adminAuth checks the Authorization: Bearer <ADMIN_TOKEN> header before
+// delegating to the wrapped handler.
+func adminAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if adminToken == "" {
+			http.Error(w, "admin endpoints are not configured", http.StatusServiceUnavailable)
+			return
+		}
+		got := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if got == "" || subtle.ConstantTimeCompare([]byte(got), []byte(adminToken)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// buildOrderFilter turns the admin query parameters into a Mongo filter
+// shared by the list, CSV, and RSS endpoints.
+func buildOrderFilter(r *http.Request) (bson.M, error) {
+	q := r.URL.Query()
+	filter := bson.M{}
+
+	if v := q.Get("productType"); v != "" {
+		filter["productType"] = v
+	}
+	if v := q.Get("orderType"); v != "" {
+		filter["orderType"] = v
+	}
+	if v := q.Get("companyName"); v != "" {
+		filter["companyName"] = v
+	}
+
+	deliveryRange := bson.M{}
+	if v := q.Get("deliveryFrom"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("deliveryFrom must be RFC3339: %w", err)
+		}
+		deliveryRange["$gte"] = from
+	}
+	if v := q.Get("deliveryTo"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("deliveryTo must be RFC3339: %w", err)
+		}
+		deliveryRange["$lte"] = to
+	}
+	if len(deliveryRange) > 0 {
+		filter["deliveryDateTime"] = deliveryRange
+	}
+
+	return filter, nil
+}
+
+// sortOptions reads sortBy/order query params, defaulting to newest
+// deliveries first.
+func sortOptions(r *http.Request) bson.D {
+	sortBy := r.URL.Query().Get("sortBy")
+	switch sortBy {
+	case "createdAt", "companyName", "productType":
+		// allowed
+	default:
+		sortBy = "deliveryDateTime"
+	}
+
+	dir := -1
+	if r.URL.Query().Get("order") == "asc" {
+		dir = 1
+	}
+	return bson.D{{Key: sortBy, Value: dir}}
+}
+
+// ordersHandler lists orders with pagination, filtering, and sorting.
+func ordersHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := buildOrderFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, pageSize := paginationParams(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	total, err := orderColl.CountDocuments(ctx, filter)
+	if err != nil {
+		log.Printf("Error counting orders: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	findOpts := options.Find().
+		SetSort(sortOptions(r)).
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize))
+
+	cur, err := orderColl.Find(ctx, filter, findOpts)
+	if err != nil {
+		log.Printf("Error listing orders: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer cur.Close(ctx)
+
+	var orders []Order
+	if err := cur.All(ctx, &orders); err != nil {
+		log.Printf("Error decoding orders: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"orders":   orders,
+		"page":     page,
+		"pageSize": pageSize,
+		"total":    total,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// paginationParams reads page/pageSize query params, clamping pageSize to
+// maxPageSize.
+func paginationParams(r *http.Request) (page, pageSize int) {
+	page = 1
+	if v, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && v > 0 {
+		page = v
+	}
+
+	pageSize = defaultPageSize
+	if v, err := strconv.Atoi(r.URL.Query().Get("pageSize")); err == nil && v > 0 {
+		pageSize = v
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+// orderByIDHandler serves a single order by its Mongo ObjectID, given at
+// /orders/{id}.
+func orderByIDHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/orders/")
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		http.Error(w, "Invalid order id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var order Order
+	if err := orderColl.FindOne(ctx, bson.M{"_id": objID}).Decode(&order); err != nil {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(order)
+}
+
+// ordersCSVHandler streams the filtered orders as a CSV export.
+func ordersCSVHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := buildOrderFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cur, err := orderColl.Find(ctx, filter, options.Find().SetSort(sortOptions(r)))
+	if err != nil {
+		log.Printf("Error exporting orders: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer cur.Close(ctx)
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=orders.csv")
+
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	cw.Write([]string{
+		"id", "productType", "subOption", "orderType", "brandName", "quantity",
+		"size", "companyName", "email", "phoneNumber", "address",
+		"deliveryDateTime", "createdAt",
+	})
+
+	for cur.Next(ctx) {
+		var o Order
+		if err := cur.Decode(&o); err != nil {
+			log.Printf("Error decoding order for CSV export: %v", err)
+			continue
+		}
+		cw.Write([]string{
+			o.ID.Hex(), o.ProductType, o.SubOption, o.OrderType, o.BrandName, o.Quantity,
+			o.Size, o.CompanyName, o.Email, o.PhoneNumber, o.Address,
+			o.DeliveryDateTime.Format(time.RFC3339), o.CreatedAt.Format(time.RFC3339),
+		})
+	}
+}
+
+// rssFeed and rssItem model the minimal RSS 2.0 structure needed for the
+// orders feed.
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	GUID        string `xml:"guid"`
+	PubDate     string `xml:"pubDate"`
+	Description string `xml:"description"`
+}
+
+// ordersRSSHandler renders recent orders as an RSS 2.0 feed for internal
+// dashboards.
+func ordersRSSHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter, err := buildOrderFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: "deliveryDateTime", Value: -1}}).
+		SetLimit(rssMaxItems)
+
+	cur, err := orderColl.Find(ctx, filter, findOpts)
+	if err != nil {
+		log.Printf("Error building RSS feed: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer cur.Close(ctx)
+
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       "Sipper Form Orders",
+			Description: "Recent orders submitted through the Sipper form",
+		},
+	}
+
+	for cur.Next(ctx) {
+		var o Order
+		if err := cur.Decode(&o); err != nil {
+			log.Printf("Error decoding order for RSS feed: %v", err)
+			continue
+		}
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			GUID:    o.ID.Hex(),
+			PubDate: o.DeliveryDateTime.Format(time.RFC1123Z),
+			Description: fmt.Sprintf("%s x%s for %s (delivery %s)",
+				o.ProductType, o.Quantity, o.CompanyName, o.DeliveryDateTime.Format(time.RFC3339)),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml")
+	w.Write([]byte(xml.Header))
+	if err := xml.NewEncoder(w).Encode(feed); err != nil {
+		log.Printf("Error encoding RSS feed: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Package config loads the service's settings from a TOML file, with
+// environment variables overriding any value present in the file. It is
+// meant to replace scattered os.Getenv calls with a single, validated
+// struct loaded once at startup.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+// SMTP holds the outbound mail server settings.
+type SMTP struct {
+	Host string `toml:"host"`
+	Port string `toml:"port"`
+	User string `toml:"user"`
+	Pass string `toml:"pass"`
+	From string `toml:"from"`
+}
+
+// Scheduler holds the delivery slot validation settings.
+type Scheduler struct {
+	BusinessTZ       string `toml:"business_tz"`
+	MinLeadHours     int    `toml:"min_lead_hours"`
+	MaxOrdersPerSlot int    `toml:"max_orders_per_slot"`
+	SlotMinutes      int    `toml:"slot_minutes"`
+	WorkingHours     string `toml:"working_hours"`
+}
+
+// Config is the service's full set of startup settings.
+type Config struct {
+	MongoURI   string    `toml:"mongo_uri"`
+	Port       string    `toml:"port"`
+	AdminToken string    `toml:"admin_token"`
+	InvoiceDir string    `toml:"invoice_dir"`
+	AdminEmail string    `toml:"admin_email"`
+	DigestHour int       `toml:"digest_hour"`
+	SMTP       SMTP      `toml:"smtp"`
+	Scheduler  Scheduler `toml:"scheduler"`
+}
+
+// defaults returns a Config pre-filled with the same defaults main used to
+// apply inline.
+func defaults() Config {
+	return Config{
+		Port:       "8080",
+		DigestHour: 2,
+		Scheduler: Scheduler{
+			BusinessTZ:       "UTC",
+			MinLeadHours:     2,
+			MaxOrdersPerSlot: 5,
+			SlotMinutes:      30,
+			WorkingHours:     "Mon-Sat:09:00-18:00",
+		},
+	}
+}
+
+// Load reads path (if it exists) as TOML, applies environment variable
+// overrides, and validates the result. A missing file is not an error —
+// it's the common case when every setting comes from the environment.
+func Load(path string) (*Config, error) {
+	cfg := defaults()
+
+	if _, err := os.Stat(path); err == nil {
+		if _, err := toml.DecodeFile(path, &cfg); err != nil {
+			return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading config file %s: %w", path, err)
+	}
+
+	applyEnvOverrides(&cfg)
+
+	if cfg.MongoURI == "" {
+		return nil, fmt.Errorf("mongo_uri is required (set it in %s or MONGODB_URI)", path)
+	}
+
+	return &cfg, nil
+}
+
+// applyEnvOverrides lets environment variables win over whatever the config
+// file set, matching the precedence operators expect from 12-factor apps.
+func applyEnvOverrides(cfg *Config) {
+	override(&cfg.MongoURI, "MONGODB_URI")
+	override(&cfg.Port, "PORT")
+	override(&cfg.AdminToken, "ADMIN_TOKEN")
+	override(&cfg.InvoiceDir, "INVOICE_DIR")
+	override(&cfg.AdminEmail, "ADMIN_EMAIL")
+	overrideInt(&cfg.DigestHour, "DIGEST_HOUR", 0)
+
+	override(&cfg.SMTP.Host, "SMTP_HOST")
+	override(&cfg.SMTP.Port, "SMTP_PORT")
+	override(&cfg.SMTP.User, "SMTP_USER")
+	override(&cfg.SMTP.Pass, "SMTP_PASS")
+	override(&cfg.SMTP.From, "SMTP_FROM")
+
+	override(&cfg.Scheduler.BusinessTZ, "BUSINESS_TZ")
+	override(&cfg.Scheduler.WorkingHours, "WORKING_HOURS")
+	overrideInt(&cfg.Scheduler.MinLeadHours, "MIN_LEAD_HOURS", 0)
+	overrideInt(&cfg.Scheduler.MaxOrdersPerSlot, "MAX_ORDERS_PER_SLOT", 1)
+	overrideInt(&cfg.Scheduler.SlotMinutes, "SLOT_MINUTES", 1)
+}
+
+func override(dst *string, envVar string) {
+	if v := os.Getenv(envVar); v != "" {
+		*dst = v
+	}
+}
+
+// overrideInt applies envVar over dst, same as override, but only when the
+// value parses as a whole number no smaller than min. Anything else
+// (unset, non-numeric, trailing garbage, too small) is ignored and dst
+// keeps its prior value.
+func overrideInt(dst *int, envVar string, min int) {
+	v := os.Getenv(envVar)
+	if v == "" {
+		return
+	}
+	if parsed, err := strconv.Atoi(v); err == nil && parsed >= min {
+		*dst = parsed
+	}
+}
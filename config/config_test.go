@@ -0,0 +1,95 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadPrecedence(t *testing.T) {
+	t.Run("defaults apply with no file and no env", func(t *testing.T) {
+		cfg, err := Load(filepath.Join(t.TempDir(), "missing.toml"))
+		if err == nil {
+			t.Fatalf("Load() error = nil, want error (mongo_uri unset)")
+		}
+		_ = cfg
+	})
+
+	t.Run("file values override defaults", func(t *testing.T) {
+		path := writeTOML(t, `
+mongo_uri = "mongodb://file-host/db"
+port = "9090"
+
+[scheduler]
+business_tz = "America/New_York"
+`)
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Port != "9090" {
+			t.Errorf("Port = %q, want %q", cfg.Port, "9090")
+		}
+		if cfg.Scheduler.BusinessTZ != "America/New_York" {
+			t.Errorf("BusinessTZ = %q, want %q", cfg.Scheduler.BusinessTZ, "America/New_York")
+		}
+		// Untouched default still holds.
+		if cfg.Scheduler.MaxOrdersPerSlot != 5 {
+			t.Errorf("MaxOrdersPerSlot = %d, want default 5", cfg.Scheduler.MaxOrdersPerSlot)
+		}
+	})
+
+	t.Run("env overrides both file and default", func(t *testing.T) {
+		path := writeTOML(t, `
+mongo_uri = "mongodb://file-host/db"
+port = "9090"
+`)
+		t.Setenv("MONGODB_URI", "mongodb://env-host/db")
+		t.Setenv("PORT", "7070")
+		t.Setenv("MAX_ORDERS_PER_SLOT", "10")
+
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.MongoURI != "mongodb://env-host/db" {
+			t.Errorf("MongoURI = %q, want env value", cfg.MongoURI)
+		}
+		if cfg.Port != "7070" {
+			t.Errorf("Port = %q, want env value 7070", cfg.Port)
+		}
+		if cfg.Scheduler.MaxOrdersPerSlot != 10 {
+			t.Errorf("MaxOrdersPerSlot = %d, want env value 10", cfg.Scheduler.MaxOrdersPerSlot)
+		}
+	})
+
+	t.Run("invalid or too-small numeric env is ignored", func(t *testing.T) {
+		path := writeTOML(t, `mongo_uri = "mongodb://file-host/db"`)
+		t.Setenv("MAX_ORDERS_PER_SLOT", "0")
+		t.Setenv("SLOT_MINUTES", "not-a-number")
+		t.Setenv("MIN_LEAD_HOURS", "3abc")
+
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Scheduler.MaxOrdersPerSlot != 5 {
+			t.Errorf("MaxOrdersPerSlot = %d, want default 5 (0 should be rejected)", cfg.Scheduler.MaxOrdersPerSlot)
+		}
+		if cfg.Scheduler.SlotMinutes != 30 {
+			t.Errorf("SlotMinutes = %d, want default 30 (non-numeric should be rejected)", cfg.Scheduler.SlotMinutes)
+		}
+		if cfg.Scheduler.MinLeadHours != 2 {
+			t.Errorf("MinLeadHours = %d, want default 2 (trailing garbage should be rejected)", cfg.Scheduler.MinLeadHours)
+		}
+	})
+}
+
+func writeTOML(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing test config: %v", err)
+	}
+	return path
+}
@@ -0,0 +1,204 @@
+// Package email sends order-related notifications over SMTP. Sends are
+// queued and handled by a small worker pool so callers (HTTP handlers, the
+// nightly digest job) never block on mail delivery.
+package email
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Config holds the SMTP connection details and sender identity, loaded from
+// the environment by the caller.
+type Config struct {
+	Host      string
+	Port      string
+	User      string
+	Pass      string
+	From      string
+	QueueSize int
+	Workers   int
+}
+
+// Order carries the subset of order fields needed to compose notification
+// emails.
+type Order struct {
+	ID                  string
+	ProductType         string
+	SubOption           string
+	OrderType           string
+	BrandName           string
+	Quantity            string
+	CompanyName         string
+	Email               string
+	DeliveryDateTime    time.Time
+	SpecialInstructions string
+}
+
+// Message is a single email to send, with plaintext and HTML bodies so
+// clients can render whichever they prefer.
+type Message struct {
+	To       []string
+	Subject  string
+	TextBody string
+	HTMLBody string
+}
+
+// Mailer queues and sends Messages using a fixed worker pool.
+type Mailer struct {
+	cfg   Config
+	queue chan Message
+}
+
+// NewMailer builds a Mailer and starts its worker pool. Callers should keep
+// a single long-lived Mailer for the life of the process.
+func NewMailer(cfg Config) *Mailer {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = 100
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = 2
+	}
+
+	m := &Mailer{
+		cfg:   cfg,
+		queue: make(chan Message, cfg.QueueSize),
+	}
+	for i := 0; i < cfg.Workers; i++ {
+		go m.worker()
+	}
+	return m
+}
+
+func (m *Mailer) worker() {
+	for msg := range m.queue {
+		if err := m.send(msg); err != nil {
+			log.Printf("Error sending email %q to %v: %v", msg.Subject, msg.To, err)
+		}
+	}
+}
+
+// Enqueue schedules msg for delivery without blocking the caller. If the
+// queue is full the message is dropped and logged, rather than blocking an
+// HTTP request indefinitely.
+func (m *Mailer) Enqueue(msg Message) {
+	select {
+	case m.queue <- msg:
+	default:
+		log.Printf("Email queue full, dropping message %q to %v", msg.Subject, msg.To)
+	}
+}
+
+// send delivers a single message over SMTP as a multipart/alternative
+// text+HTML email.
+func (m *Mailer) send(msg Message) error {
+	addr := fmt.Sprintf("%s:%s", m.cfg.Host, m.cfg.Port)
+
+	var auth smtp.Auth
+	if m.cfg.User != "" {
+		auth = smtp.PlainAuth("", m.cfg.User, m.cfg.Pass, m.cfg.Host)
+	}
+
+	const boundary = "sipper-boundary-42"
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", sanitizeHeaderValue(m.cfg.From))
+	fmt.Fprintf(&b, "To: %s\r\n", sanitizeHeaderValue(strings.Join(msg.To, ", ")))
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeaderValue(msg.Subject))
+	fmt.Fprintf(&b, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", boundary)
+
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.TextBody)
+	b.WriteString("\r\n\r\n")
+
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
+	b.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.HTMLBody)
+	b.WriteString("\r\n\r\n")
+
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
+
+	return smtp.SendMail(addr, auth, m.cfg.From, msg.To, []byte(b.String()))
+}
+
+// sanitizeHeaderValue strips CR and LF from a value bound for a raw header
+// line, so a CRLF smuggled in a user-controlled field (company name,
+// product type, customer email) can't inject additional SMTP headers such
+// as a forged Bcc.
+func sanitizeHeaderValue(v string) string {
+	v = strings.ReplaceAll(v, "\r", "")
+	v = strings.ReplaceAll(v, "\n", "")
+	return v
+}
+
+// OrderConfirmation builds the customer-facing confirmation email for a
+// newly accepted order.
+func OrderConfirmation(o Order) Message {
+	subject := fmt.Sprintf("Order received: %s x%s", o.ProductType, o.Quantity)
+	text := fmt.Sprintf(
+		"Thanks for your order, %s.\n\nProduct: %s (%s)\nQuantity: %s\nDelivery: %s\n\nOrder ID: %s\n",
+		o.CompanyName, o.ProductType, o.SubOption, o.Quantity, o.DeliveryDateTime.Format(time.RFC1123), o.ID,
+	)
+	htmlBody := fmt.Sprintf(
+		"<p>Thanks for your order, %s.</p><ul><li>Product: %s (%s)</li><li>Quantity: %s</li><li>Delivery: %s</li></ul><p>Order ID: %s</p>",
+		html.EscapeString(o.CompanyName), html.EscapeString(o.ProductType), html.EscapeString(o.SubOption),
+		html.EscapeString(o.Quantity), o.DeliveryDateTime.Format(time.RFC1123), o.ID,
+	)
+	return Message{
+		To:       []string{o.Email},
+		Subject:  subject,
+		TextBody: text,
+		HTMLBody: htmlBody,
+	}
+}
+
+// AdminNotification builds the internal notification email sent when a new
+// order is accepted.
+func AdminNotification(adminEmail string, o Order) Message {
+	subject := fmt.Sprintf("New order: %s (%s)", o.CompanyName, o.OrderType)
+	text := fmt.Sprintf(
+		"New order from %s.\n\nProduct: %s (%s)\nOrder type: %s\nQuantity: %s\nDelivery: %s\nOrder ID: %s\n",
+		o.CompanyName, o.ProductType, o.SubOption, o.OrderType, o.Quantity, o.DeliveryDateTime.Format(time.RFC1123), o.ID,
+	)
+	htmlBody := fmt.Sprintf(
+		"<p>New order from <strong>%s</strong>.</p><ul><li>Product: %s (%s)</li><li>Order type: %s</li><li>Quantity: %s</li><li>Delivery: %s</li></ul><p>Order ID: %s</p>",
+		html.EscapeString(o.CompanyName), html.EscapeString(o.ProductType), html.EscapeString(o.SubOption),
+		html.EscapeString(o.OrderType), html.EscapeString(o.Quantity), o.DeliveryDateTime.Format(time.RFC1123), o.ID,
+	)
+	return Message{
+		To:       []string{adminEmail},
+		Subject:  subject,
+		TextBody: text,
+		HTMLBody: htmlBody,
+	}
+}
+
+// Digest builds the nightly admin summary of orders due within the next 24
+// hours. Callers should skip enqueuing it when orders is empty.
+func Digest(adminEmail string, orders []Order) Message {
+	subject := fmt.Sprintf("Deliveries in the next 24 hours: %d", len(orders))
+
+	var text, htmlBody strings.Builder
+	fmt.Fprintf(&text, "%d orders are due for delivery in the next 24 hours:\n\n", len(orders))
+	htmlBody.WriteString("<p>Orders due for delivery in the next 24 hours:</p><ul>")
+	for _, o := range orders {
+		fmt.Fprintf(&text, "- %s: %s x%s for %s (%s)\n",
+			o.DeliveryDateTime.Format(time.RFC1123), o.ProductType, o.Quantity, o.CompanyName, o.ID)
+		fmt.Fprintf(&htmlBody, "<li>%s: %s x%s for %s (%s)</li>",
+			o.DeliveryDateTime.Format(time.RFC1123), html.EscapeString(o.ProductType), html.EscapeString(o.Quantity),
+			html.EscapeString(o.CompanyName), o.ID)
+	}
+	htmlBody.WriteString("</ul>")
+
+	return Message{
+		To:       []string{adminEmail},
+		Subject:  subject,
+		TextBody: text.String(),
+		HTMLBody: htmlBody.String(),
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Gautam3767/Sipper_Form_Backend/email"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// digestInterval is how often the nightly digest job runs.
+const digestInterval = 24 * time.Hour
+
+// toEmailOrder maps the Mongo Order document onto the email package's
+// notification struct.
+func toEmailOrder(o Order) email.Order {
+	return email.Order{
+		ID:                  o.ID.Hex(),
+		ProductType:         o.ProductType,
+		SubOption:           o.SubOption,
+		OrderType:           o.OrderType,
+		BrandName:           o.BrandName,
+		Quantity:            o.Quantity,
+		CompanyName:         o.CompanyName,
+		Email:               o.Email,
+		DeliveryDateTime:    o.DeliveryDateTime,
+		SpecialInstructions: o.SpecialInstructions,
+	}
+}
+
+// notifyOrderCreated enqueues the customer confirmation and, if configured,
+// the admin notification for a just-inserted order.
+func notifyOrderCreated(o Order) {
+	eo := toEmailOrder(o)
+	mailer.Enqueue(email.OrderConfirmation(eo))
+	if adminEmail != "" {
+		mailer.Enqueue(email.AdminNotification(adminEmail, eo))
+	}
+}
+
+// runDigestSchedule sends the admin digest once a day at digestHour (local
+// time, 0-23), and every digestInterval after that. It is started as a
+// background goroutine from main and never returns.
+func runDigestSchedule(digestHour int) {
+	if adminEmail == "" {
+		return
+	}
+
+	for {
+		time.Sleep(time.Until(nextDigestRun(time.Now(), digestHour)))
+		sendDigest()
+	}
+}
+
+// nextDigestRun returns the next time at or after now that falls on
+// digestHour:00:00, rolling over to the following day if that time has
+// already passed today.
+func nextDigestRun(now time.Time, digestHour int) time.Time {
+	hour := ((digestHour % 24) + 24) % 24
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// sendDigest emails admins a summary of orders due for delivery in the next
+// 24 hours.
+func sendDigest() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{
+		"deliveryDateTime": bson.M{
+			"$gte": now,
+			"$lte": now.Add(digestInterval),
+		},
+	}
+
+	cur, err := orderColl.Find(ctx, filter)
+	if err != nil {
+		log.Printf("Error building order digest: %v", err)
+		return
+	}
+	defer cur.Close(ctx)
+
+	var orders []Order
+	if err := cur.All(ctx, &orders); err != nil {
+		log.Printf("Error decoding orders for digest: %v", err)
+		return
+	}
+	if len(orders) == 0 {
+		return
+	}
+
+	emailOrders := make([]email.Order, 0, len(orders))
+	for _, o := range orders {
+		emailOrders = append(emailOrders, toEmailOrder(o))
+	}
+	mailer.Enqueue(email.Digest(adminEmail, emailOrders))
+}
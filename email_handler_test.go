@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDigestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		now        time.Time
+		digestHour int
+		want       time.Time
+	}{
+		{
+			name:       "before the hour rolls to today",
+			now:        time.Date(2026, 7, 25, 1, 0, 0, 0, time.UTC),
+			digestHour: 2,
+			want:       time.Date(2026, 7, 25, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "after the hour rolls to tomorrow",
+			now:        time.Date(2026, 7, 25, 3, 0, 0, 0, time.UTC),
+			digestHour: 2,
+			want:       time.Date(2026, 7, 26, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "exactly on the hour rolls to tomorrow",
+			now:        time.Date(2026, 7, 25, 2, 0, 0, 0, time.UTC),
+			digestHour: 2,
+			want:       time.Date(2026, 7, 26, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "out-of-range hour wraps",
+			now:        time.Date(2026, 7, 25, 0, 0, 0, 0, time.UTC),
+			digestHour: 26,
+			want:       time.Date(2026, 7, 25, 2, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextDigestRun(tt.now, tt.digestHour)
+			if !got.Equal(tt.want) {
+				t.Fatalf("nextDigestRun(%v, %d) = %v, want %v", tt.now, tt.digestHour, got, tt.want)
+			}
+		})
+	}
+}
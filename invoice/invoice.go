@@ -0,0 +1,106 @@
+// Package invoice renders PDF order confirmations. It is kept independent
+// of the main package's Mongo/HTTP concerns so it can be reused by both the
+// synchronous download endpoint and the asynchronous post-insert job.
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Order carries the subset of order fields needed to render a confirmation.
+type Order struct {
+	ID                  string
+	ProductType         string
+	SubOption           string
+	OrderType           string
+	BrandName           string
+	Quantity            string
+	Size                string
+	CompanyName         string
+	Email               string
+	PhoneNumber         string
+	Address             string
+	SpecialInstructions string
+	DeliveryDateTime    time.Time
+}
+
+// Render builds a one-page PDF order confirmation and returns its bytes.
+func Render(o Order) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddPage()
+
+	pdf.SetFont("Arial", "B", 16)
+	pdf.CellFormat(0, 10, "Order Confirmation", "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Order ID: %s", o.ID), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Product Details", "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Product type: %s (%s)", o.ProductType, o.SubOption), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("Order type: %s", o.OrderType), "", 1, "L", false, 0, "")
+	if o.BrandName != "" {
+		pdf.CellFormat(0, 6, fmt.Sprintf("Brand name: %s", o.BrandName), "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, 6, fmt.Sprintf("Quantity: %s", o.Quantity), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("Size: %s", o.Size), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Delivery", "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Delivery date/time: %s", o.DeliveryDateTime.Format(time.RFC1123)), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Arial", "B", 12)
+	pdf.CellFormat(0, 8, "Company", "", 1, "L", false, 0, "")
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 6, o.CompanyName, "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, o.Address, "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("%s / %s", o.Email, o.PhoneNumber), "", 1, "L", false, 0, "")
+
+	if o.SpecialInstructions != "" {
+		pdf.Ln(4)
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(0, 8, "Special Instructions", "", 1, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		pdf.MultiCell(0, 6, o.SpecialInstructions, "", "L", false)
+	}
+
+	pdf.Ln(8)
+	pdf.SetFont("Arial", "I", 8)
+	pdf.MultiCell(0, 5, "This is an automatically generated confirmation. Delivery terms and pricing are subject to the agreement in place between Sipper and the ordering company.", "", "L", false)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("rendering invoice pdf: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteToDir renders the order confirmation and writes it to dir, returning
+// the path written. The filename is derived from the order ID so repeat
+// calls overwrite the same file.
+func WriteToDir(dir string, o Order) (string, error) {
+	data, err := Render(o)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating invoice dir: %w", err)
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s.pdf", o.ID))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("writing invoice file: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Gautam3767/Sipper_Form_Backend/invoice"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// toInvoiceOrder maps the Mongo Order document onto the invoice package's
+// rendering struct.
+func toInvoiceOrder(o Order) invoice.Order {
+	return invoice.Order{
+		ID:                  o.ID.Hex(),
+		ProductType:         o.ProductType,
+		SubOption:           o.SubOption,
+		OrderType:           o.OrderType,
+		BrandName:           o.BrandName,
+		Quantity:            o.Quantity,
+		Size:                o.Size,
+		CompanyName:         o.CompanyName,
+		Email:               o.Email,
+		PhoneNumber:         o.PhoneNumber,
+		Address:             o.Address,
+		SpecialInstructions: o.SpecialInstructions,
+		DeliveryDateTime:    o.DeliveryDateTime,
+	}
+}
+
+// generateInvoiceAsync writes the order's PDF confirmation to invoiceDir
+// and records its path on the document. It runs in its own goroutine so the
+// orderHandler response is never delayed by PDF rendering or disk I/O.
+func generateInvoiceAsync(o Order) {
+	if invoiceDir == "" {
+		return
+	}
+
+	path, err := invoice.WriteToDir(invoiceDir, toInvoiceOrder(o))
+	if err != nil {
+		log.Printf("Error generating invoice for order %s: %v", o.ID.Hex(), err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = orderColl.UpdateOne(ctx,
+		bson.M{"_id": o.ID},
+		bson.M{"$set": bson.M{"invoicePath": path}},
+	)
+	if err != nil {
+		log.Printf("Error saving invoice path for order %s: %v", o.ID.Hex(), err)
+	}
+}
+
+// orderSubrouteHandler dispatches requests under /orders/ to either the
+// single-order lookup or the invoice download, based on the trailing path
+// segment.
+func orderSubrouteHandler(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/orders/")
+	if strings.HasSuffix(rest, "/invoice.pdf") {
+		id := strings.TrimSuffix(rest, "/invoice.pdf")
+		invoicePDFHandler(w, r, id)
+		return
+	}
+	orderByIDHandler(w, r)
+}
+
+// invoicePDFHandler streams a freshly rendered PDF confirmation for the
+// given order ID.
+func invoicePDFHandler(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		http.Error(w, "Invalid order id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var order Order
+	if err := orderColl.FindOne(ctx, bson.M{"_id": objID}).Decode(&order); err != nil {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	data, err := invoice.Render(toInvoiceOrder(order))
+	if err != nil {
+		log.Printf("Error rendering invoice for order %s: %v", id, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "inline; filename=\""+id+"-invoice.pdf\"")
+	w.Write(data)
+}
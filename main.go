@@ -3,15 +3,20 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"strconv"
 	"time"
 
+	"github.com/Gautam3767/Sipper_Form_Backend/config"
+	"github.com/Gautam3767/Sipper_Form_Backend/email"
+	"github.com/Gautam3767/Sipper_Form_Backend/metrics"
+	"github.com/Gautam3767/Sipper_Form_Backend/openapi"
+	"github.com/Gautam3767/Sipper_Form_Backend/scheduler"
+	"github.com/Gautam3767/Sipper_Form_Backend/webhooks"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -37,32 +42,62 @@ type Order struct {
 	CreatedAt           time.Time          `bson:"createdAt" json:"createdAt"`
 	// DeliveryDateTime is the parsed combination of delivery date and time.
 	DeliveryDateTime time.Time `bson:"deliveryDateTime" json:"deliveryDateTime"`
+	// InvoicePath is the location of the generated PDF confirmation, set
+	// asynchronously after insert when INVOICE_DIR is configured.
+	InvoicePath string `bson:"invoicePath,omitempty" json:"invoicePath,omitempty"`
 }
 
 var (
 	client    *mongo.Client
 	orderColl *mongo.Collection
+
+	// invoiceDir is the directory async-generated invoice PDFs are written
+	// to. Empty disables the background write.
+	invoiceDir string
+
+	// mailer sends order confirmation, admin notification, and digest
+	// emails in the background.
+	mailer *email.Mailer
+
+	// adminEmail receives new-order notifications and the nightly digest.
+	adminEmail string
+
+	// sched validates requested delivery slots against the business
+	// timezone, working hours, lead time, and per-slot capacity.
+	sched *scheduler.Scheduler
+
+	// apiSpec is the OpenAPI contract for /order, used to validate request
+	// bodies and to serve /openapi.json and /docs.
+	apiSpec *openapi.Spec
+
+	// webhookDispatcher fans order events out to registered subscribers
+	// and drives their delivery retries in the background.
+	webhookDispatcher *webhooks.Dispatcher
+
+	// webhookSubscribersColl and webhookDeliveriesColl back the admin
+	// subscriber/delivery endpoints; webhookDispatcher holds its own
+	// references to the same collections for dispatch and retries.
+	webhookSubscribersColl *mongo.Collection
+	webhookDeliveriesColl  *mongo.Collection
 )
 
 func main() {
-	// Load environment variables from .env file.
-	err := godotenv.Load()
-	if err != nil {
+	// Load environment variables from .env file; config.Load then applies
+	// them as overrides on top of the config file.
+	if err := godotenv.Load(); err != nil {
 		log.Println("Warning: Could not load .env file")
 	}
 
-	// Retrieve MongoDB URI and PORT from environment variables.
-	mongoURI := os.Getenv("MONGODB_URI")
-	if mongoURI == "" {
-		log.Fatal("MONGODB_URI not set in environment")
+	configFile := os.Getenv("CONFIG_FILE")
+	if configFile == "" {
+		configFile = "config.toml"
 	}
-
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		log.Fatalf("Error loading config: %v", err)
 	}
 
-	clientOptions := options.Client().ApplyURI(mongoURI)
+	clientOptions := options.Client().ApplyURI(cfg.MongoURI)
 	client, err = mongo.Connect(context.Background(), clientOptions)
 	if err != nil {
 		log.Fatalf("Error connecting to MongoDB: %v", err)
@@ -76,20 +111,77 @@ func main() {
 	// Use a specific database and collection.
 	orderColl = client.Database("orderdb").Collection("orders")
 
-	// Setup HTTP endpoint with CORS middleware.
-	handler := enableCors(http.HandlerFunc(orderHandler))
-	http.Handle("/order", handler)
+	// Admin endpoints are gated by a bearer token from the config.
+	adminToken = cfg.AdminToken
+	if adminToken == "" {
+		log.Println("Warning: admin_token not set, admin endpoints are disabled")
+	}
+
+	// invoice_dir enables writing a PDF confirmation to disk after each
+	// order is inserted.
+	invoiceDir = cfg.InvoiceDir
+
+	// SMTP configuration for order confirmation, admin notification, and
+	// digest emails.
+	adminEmail = cfg.AdminEmail
+	mailer = email.NewMailer(email.Config{
+		Host: cfg.SMTP.Host,
+		Port: cfg.SMTP.Port,
+		User: cfg.SMTP.User,
+		Pass: cfg.SMTP.Pass,
+		From: cfg.SMTP.From,
+	})
+	go runDigestSchedule(cfg.DigestHour)
+
+	// Delivery slot validation: business timezone, lead time, working
+	// hours, and per-slot capacity.
+	sched, err = buildScheduler(cfg.Scheduler)
+	if err != nil {
+		log.Fatalf("Error configuring scheduler: %v", err)
+	}
+
+	// Load the OpenAPI contract for /order: request validation plus the
+	// spec/docs endpoints.
+	apiSpec, err = openapi.Load()
+	if err != nil {
+		log.Fatalf("Error loading OpenAPI spec: %v", err)
+	}
+
+	// Outbound webhooks: subscriber registration plus a background worker
+	// pool that delivers and retries "order.created" events.
+	webhookSubscribersColl = client.Database("orderdb").Collection("webhooks")
+	webhookDeliveriesColl = client.Database("orderdb").Collection("webhook_deliveries")
+	webhookDispatcher = webhooks.New(webhookSubscribersColl, webhookDeliveriesColl)
+	go webhookDispatcher.RunWorker(context.Background(), 5*time.Second)
 
-	log.Printf("Server starting on port %s...", port)
-	log.Fatal(http.ListenAndServe(":"+port, nil))
+	// Setup HTTP endpoints with CORS middleware.
+	http.Handle("/order", enableCors(apiSpec.ValidateRequest(orderHandler)))
+	http.Handle("/orders", enableCors(adminAuth(ordersHandler)))
+	http.Handle("/orders/", enableCors(adminAuth(orderSubrouteHandler)))
+	http.Handle("/orders.csv", enableCors(adminAuth(ordersCSVHandler)))
+	http.Handle("/orders.rss", enableCors(adminAuth(ordersRSSHandler)))
+	http.Handle("/openapi.json", enableCors(http.HandlerFunc(apiSpec.SpecHandler)))
+	http.Handle("/docs", enableCors(http.HandlerFunc(openapi.DocsHandler)))
+	http.Handle("/webhooks", enableCors(adminAuth(webhookSubscribersHandler)))
+	http.Handle("/webhooks/deliveries", enableCors(adminAuth(webhookDeliveriesHandler)))
+	http.Handle("/webhooks/deliveries/", enableCors(adminAuth(webhookReplayHandler)))
+	http.HandleFunc("/healthz", healthzHandler)
+	http.HandleFunc("/readyz", readyzHandler)
+	http.Handle("/metrics", promhttp.Handler())
+
+	log.Printf("Server starting on port %s...", cfg.Port)
+	log.Fatal(http.ListenAndServe(":"+cfg.Port, nil))
 }
 
-// enableCors adds CORS headers to the response.
+// enableCors adds CORS headers to the response. It's shared by /order and
+// the admin/export/webhook routes, so it allows both the POST body the
+// order form sends and the GET + Authorization bearer token the admin
+// dashboards send.
 func enableCors(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -101,6 +193,9 @@ func enableCors(next http.Handler) http.Handler {
 
 // orderHandler processes incoming POST requests with order data.
 func orderHandler(w http.ResponseWriter, r *http.Request) {
+	metrics.InFlightRequests.Inc()
+	defer metrics.InFlightRequests.Dec()
+
 	if r.Method != http.MethodPost {
 		http.Error(w, "Only POST is allowed", http.StatusMethodNotAllowed)
 		return
@@ -108,28 +203,50 @@ func orderHandler(w http.ResponseWriter, r *http.Request) {
 
 	var order Order
 	if err := json.NewDecoder(r.Body).Decode(&order); err != nil {
+		metrics.ValidationFailures.WithLabelValues("decode").Inc()
 		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
 		return
 	}
 	defer r.Body.Close()
 
-	// Validate the order payload.
-	if err := validateOrder(order); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+	// Field presence, quantity format, enum values (orderType), and email
+	// format were already enforced by the OpenAPI request validation
+	// middleware. The minimum-quantity-for-Existing-Brand rule is a
+	// cross-field business rule the schema can't express, so it stays here.
+	if order.OrderType == "Existing Brand" {
+		if order.BrandName == "" {
+			metrics.ValidationFailures.WithLabelValues("existing_brand_name").Inc()
+			http.Error(w, "brandName is required for Existing Brand orders", http.StatusBadRequest)
+			return
+		}
+		quantity, _ := strconv.Atoi(order.Quantity)
+		if quantity < 1000 {
+			metrics.ValidationFailures.WithLabelValues("existing_brand_quantity").Inc()
+			http.Error(w, "quantity must be at least 1000 for Existing Brand orders", http.StatusBadRequest)
+			return
+		}
 	}
 
-	// Convert quantity from string to integer.
-	_, err := strconv.Atoi(order.Quantity)
+	// Parse and validate the delivery slot against the business timezone,
+	// lead time, working hours, and per-slot capacity.
+	deliveryDateTime, err := sched.ParseSlot(order.DeliveryDate, order.DeliveryTime)
 	if err != nil {
-		http.Error(w, "Quantity must be a valid number", http.StatusBadRequest)
+		metrics.ValidationFailures.WithLabelValues("scheduler").Inc()
+		writeSchedulerError(w, err)
 		return
 	}
 
-	// Parse and combine delivery date and time.
-	deliveryDateTime, err := parseDeliveryDateTime(order.DeliveryDate, order.DeliveryTime)
-	if err != nil {
-		http.Error(w, "Invalid delivery date or time format", http.StatusBadRequest)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := sched.Validate(time.Now(), deliveryDateTime); err != nil {
+		metrics.ValidationFailures.WithLabelValues("scheduler").Inc()
+		writeSchedulerError(w, err)
+		return
+	}
+	if err := sched.CheckCapacity(ctx, orderColl, deliveryDateTime); err != nil {
+		metrics.ValidationFailures.WithLabelValues("scheduler").Inc()
+		writeSchedulerError(w, err)
 		return
 	}
 
@@ -138,14 +255,22 @@ func orderHandler(w http.ResponseWriter, r *http.Request) {
 	order.DeliveryDateTime = deliveryDateTime
 
 	// Insert the order into MongoDB.
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	insertStart := time.Now()
 	res, err := orderColl.InsertOne(ctx, order)
+	metrics.ObserveInsertLatency(insertStart)
 	if err != nil {
 		log.Printf("Error inserting order: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
+	metrics.OrdersReceived.Inc()
+
+	if insertedID, ok := res.InsertedID.(primitive.ObjectID); ok {
+		order.ID = insertedID
+		go generateInvoiceAsync(order)
+		notifyOrderCreated(order)
+		go dispatchOrderCreated(order)
+	}
 
 	// Respond with order ID and confirmation.
 	response := map[string]interface{}{
@@ -155,61 +280,3 @@ func orderHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
-
-// validateOrder checks required fields and validates business logic.
-func validateOrder(o Order) error {
-	if o.ProductType == "" ||
-		o.SubOption == "" ||
-		o.Quantity == "" ||
-		o.Size == "" ||
-		o.DeliveryDate == "" ||
-		o.DeliveryTime == "" ||
-		o.CompanyName == "" ||
-		o.Email == "" ||
-		o.PhoneNumber == "" ||
-		o.Address == "" {
-		return errors.New("missing required fields")
-	}
-
-	// For "Existing Brand" orders, ensure brandName is provided and quantity meets minimum requirements.
-	if o.OrderType == "Existing Brand" {
-		if o.BrandName == "" {
-			return errors.New("brandName is required for Existing Brand orders")
-		}
-		quantity, err := strconv.Atoi(o.Quantity)
-		if err != nil {
-			return errors.New("quantity must be a valid number")
-		}
-		if quantity < 1000 {
-			return errors.New("quantity must be at least 1000 for Existing Brand orders")
-		}
-	}
-
-	// Basic email validation.
-	if !isValidEmail(o.Email) {
-		return errors.New("invalid email format")
-	}
-
-	return nil
-}
-
-// parseDeliveryDateTime combines deliveryDate and deliveryTime into a single time.Time value.
-// Assumes date format "YYYY-MM-DD" and time format "HH:MM".
-func parseDeliveryDateTime(dateStr, timeStr string) (time.Time, error) {
-	layout := "2006-01-02 15:04"
-	combined := fmt.Sprintf("%s %s", dateStr, timeStr)
-	return time.Parse(layout, combined)
-}
-
-// isValidEmail provides a basic check for the presence of "@".
-func isValidEmail(email string) bool {
-	if len(email) < 3 || len(email) > 254 {
-		return false
-	}
-	for _, c := range email {
-		if c == '@' {
-			return true
-		}
-	}
-	return false
-}
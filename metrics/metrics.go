@@ -0,0 +1,48 @@
+// Package metrics defines the Prometheus instrumentation for order intake,
+// so handlers can record outcomes without reaching into promhttp directly.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// OrdersReceived counts successfully accepted orders.
+	OrdersReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sipper_orders_received_total",
+		Help: "Total number of orders successfully accepted.",
+	})
+
+	// ValidationFailures counts rejected orders by the reason they were
+	// rejected, e.g. "schema", "existing_brand_quantity", "scheduler".
+	ValidationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sipper_order_validation_failures_total",
+		Help: "Total number of orders rejected, labeled by failure reason.",
+	}, []string{"reason"})
+
+	// InsertLatency tracks how long the Mongo InsertOne call for an order
+	// takes.
+	InsertLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "sipper_order_insert_duration_seconds",
+		Help:    "Latency of the order InsertOne call.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	// InFlightRequests tracks how many /order requests are currently being
+	// handled.
+	InFlightRequests = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "sipper_order_in_flight_requests",
+		Help: "Number of /order requests currently being processed.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(OrdersReceived, ValidationFailures, InsertLatency, InFlightRequests)
+}
+
+// ObserveInsertLatency records how long an InsertOne call took.
+func ObserveInsertLatency(start time.Time) {
+	InsertLatency.Observe(time.Since(start).Seconds())
+}
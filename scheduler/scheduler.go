@@ -0,0 +1,173 @@
+// Package scheduler validates requested delivery slots against a business
+// timezone, working hours, a minimum lead time, and a per-slot capacity
+// stored in Mongo.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// dateTimeLayout is the combined "date time" layout orders are submitted
+// in, matching the form's separate date/time fields.
+const dateTimeLayout = "2006-01-02 15:04"
+
+// Code identifies the kind of validation failure so handlers can return a
+// distinct 4xx response for each.
+type Code string
+
+const (
+	CodeInvalidFormat    Code = "invalid_format"
+	CodeNonexistentTime  Code = "nonexistent_time"
+	CodeAmbiguousTime    Code = "ambiguous_time"
+	CodeTooSoon          Code = "too_soon"
+	CodeOutsideWorkHours Code = "outside_working_hours"
+	CodeSlotFull         Code = "slot_full"
+)
+
+// Error is a structured validation failure distinguishing the specific
+// reason a delivery slot was rejected.
+type Error struct {
+	Code    Code
+	Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// WorkingHours is the open/close time for a single weekday, in "HH:MM".
+type WorkingHours struct {
+	Start string
+	End   string
+}
+
+// Config holds the business rules a Scheduler enforces.
+type Config struct {
+	// Location is the business timezone delivery slots are interpreted in.
+	Location *time.Location
+	// MinLeadTime is how far in advance a slot must be booked.
+	MinLeadTime time.Duration
+	// WorkingHours maps weekdays to their open/close window. A weekday
+	// absent from the map is treated as closed.
+	WorkingHours map[time.Weekday]WorkingHours
+	// SlotDuration is the bucket size used to group orders when checking
+	// capacity, e.g. 30 minutes.
+	SlotDuration time.Duration
+	// MaxOrdersPerSlot is how many existing orders may already occupy a
+	// bucket before a new one is rejected.
+	MaxOrdersPerSlot int
+}
+
+// Scheduler validates delivery slots against a Config.
+type Scheduler struct {
+	cfg Config
+}
+
+// New builds a Scheduler from cfg.
+func New(cfg Config) *Scheduler {
+	return &Scheduler{cfg: cfg}
+}
+
+// ParseSlot combines dateStr and timeStr in the business timezone and
+// rejects instants that the zone database says don't exist (a
+// spring-forward gap) or are ambiguous (a fall-back overlap).
+func (s *Scheduler) ParseSlot(dateStr, timeStr string) (time.Time, error) {
+	combined := fmt.Sprintf("%s %s", dateStr, timeStr)
+
+	t, err := time.ParseInLocation(dateTimeLayout, combined, s.cfg.Location)
+	if err != nil {
+		return time.Time{}, &Error{Code: CodeInvalidFormat, Message: "delivery date/time must be in YYYY-MM-DD HH:MM format"}
+	}
+
+	// time.ParseInLocation silently rolls a nonexistent wall-clock time
+	// (e.g. 02:30 on a spring-forward day) forward into the next valid
+	// instant; reformatting and comparing catches that roll.
+	if t.Format(dateTimeLayout) != combined {
+		return time.Time{}, &Error{Code: CodeNonexistentTime, Message: "delivery time does not exist in the business timezone (clocks spring forward)"}
+	}
+
+	if ambiguous := isAmbiguous(t, combined); ambiguous {
+		return time.Time{}, &Error{Code: CodeAmbiguousTime, Message: "delivery time is ambiguous in the business timezone (clocks fall back)"}
+	}
+
+	return t, nil
+}
+
+// isAmbiguous reports whether combined could also name a second, distinct
+// instant near t under the zone's other nearby UTC offset — the signature
+// of a fall-back overlap.
+func isAmbiguous(t time.Time, combined string) bool {
+	_, offset := t.Zone()
+	_, offsetBefore := t.Add(-2 * time.Hour).Zone()
+	_, offsetAfter := t.Add(2 * time.Hour).Zone()
+
+	altOffset := offset
+	switch {
+	case offsetBefore != offset:
+		altOffset = offsetBefore
+	case offsetAfter != offset:
+		altOffset = offsetAfter
+	default:
+		return false
+	}
+
+	naiveUTC := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	alt := naiveUTC.Add(-time.Duration(altOffset) * time.Second)
+	return !alt.Equal(t) && alt.In(t.Location()).Format(dateTimeLayout) == combined
+}
+
+// Validate enforces the minimum lead time and working-hours rules against
+// slot, relative to now.
+func (s *Scheduler) Validate(now, slot time.Time) error {
+	if slot.Sub(now) < s.cfg.MinLeadTime {
+		return &Error{Code: CodeTooSoon, Message: fmt.Sprintf("delivery slot must be at least %s from now", s.cfg.MinLeadTime)}
+	}
+
+	hours, open := s.cfg.WorkingHours[slot.Weekday()]
+	if !open {
+		return &Error{Code: CodeOutsideWorkHours, Message: fmt.Sprintf("%s is not a working day", slot.Weekday())}
+	}
+
+	start, err := time.ParseInLocation("15:04", hours.Start, slot.Location())
+	if err != nil {
+		return fmt.Errorf("invalid working hours start %q: %w", hours.Start, err)
+	}
+	end, err := time.ParseInLocation("15:04", hours.End, slot.Location())
+	if err != nil {
+		return fmt.Errorf("invalid working hours end %q: %w", hours.End, err)
+	}
+
+	clock := time.Date(0, 1, 1, slot.Hour(), slot.Minute(), 0, 0, slot.Location())
+	startClock := time.Date(0, 1, 1, start.Hour(), start.Minute(), 0, 0, slot.Location())
+	endClock := time.Date(0, 1, 1, end.Hour(), end.Minute(), 0, 0, slot.Location())
+	if clock.Before(startClock) || clock.After(endClock) {
+		return &Error{Code: CodeOutsideWorkHours, Message: fmt.Sprintf("delivery slot must fall between %s and %s on %s", hours.Start, hours.End, slot.Weekday())}
+	}
+
+	return nil
+}
+
+// CheckCapacity counts how many orders already fall in slot's bucket and
+// rejects the booking if it would exceed MaxOrdersPerSlot.
+func (s *Scheduler) CheckCapacity(ctx context.Context, coll *mongo.Collection, slot time.Time) error {
+	bucketStart := slot.Truncate(s.cfg.SlotDuration)
+	bucketEnd := bucketStart.Add(s.cfg.SlotDuration)
+
+	count, err := coll.CountDocuments(ctx, bson.M{
+		"deliveryDateTime": bson.M{
+			"$gte": bucketStart,
+			"$lt":  bucketEnd,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("counting orders in slot: %w", err)
+	}
+
+	if count >= int64(s.cfg.MaxOrdersPerSlot) {
+		return &Error{Code: CodeSlotFull, Message: "delivery slot is fully booked, please choose another time"}
+	}
+	return nil
+}
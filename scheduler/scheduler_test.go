@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSlot(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata not available: %v", err)
+	}
+	s := New(Config{Location: loc})
+
+	tests := []struct {
+		name     string
+		date     string
+		time     string
+		wantCode Code
+		wantErr  bool
+	}{
+		{
+			name: "ordinary time parses cleanly",
+			date: "2024-06-15",
+			time: "10:00",
+		},
+		{
+			name:     "spring-forward gap does not exist",
+			date:     "2024-03-10",
+			time:     "02:30",
+			wantErr:  true,
+			wantCode: CodeNonexistentTime,
+		},
+		{
+			name:     "fall-back hour is ambiguous",
+			date:     "2024-11-03",
+			time:     "01:30",
+			wantErr:  true,
+			wantCode: CodeAmbiguousTime,
+		},
+		{
+			name:     "malformed input",
+			date:     "not-a-date",
+			time:     "10:00",
+			wantErr:  true,
+			wantCode: CodeInvalidFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ParseSlot(tt.date, tt.time)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseSlot(%q, %q) error = nil, want error", tt.date, tt.time)
+				}
+				schedErr, ok := err.(*Error)
+				if !ok {
+					t.Fatalf("ParseSlot(%q, %q) error type = %T, want *Error", tt.date, tt.time, err)
+				}
+				if schedErr.Code != tt.wantCode {
+					t.Fatalf("ParseSlot(%q, %q) code = %q, want %q", tt.date, tt.time, schedErr.Code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSlot(%q, %q) unexpected error: %v", tt.date, tt.time, err)
+			}
+			if got.IsZero() {
+				t.Fatalf("ParseSlot(%q, %q) returned zero time", tt.date, tt.time)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	loc := time.UTC
+	s := New(Config{
+		Location:    loc,
+		MinLeadTime: 2 * time.Hour,
+		WorkingHours: map[time.Weekday]WorkingHours{
+			time.Monday: {Start: "09:00", End: "18:00"},
+		},
+	})
+
+	now := time.Date(2026, 7, 20, 8, 0, 0, 0, loc) // a Monday
+
+	tests := []struct {
+		name     string
+		slot     time.Time
+		wantCode Code
+		wantErr  bool
+	}{
+		{
+			name: "within lead time and working hours",
+			slot: time.Date(2026, 7, 20, 11, 0, 0, 0, loc),
+		},
+		{
+			name:     "too soon",
+			slot:     time.Date(2026, 7, 20, 9, 0, 0, 0, loc),
+			wantErr:  true,
+			wantCode: CodeTooSoon,
+		},
+		{
+			name:     "closed weekday",
+			slot:     time.Date(2026, 7, 21, 11, 0, 0, 0, loc), // Tuesday
+			wantErr:  true,
+			wantCode: CodeOutsideWorkHours,
+		},
+		{
+			name:     "before opening",
+			slot:     time.Date(2026, 7, 20, 8, 30, 0, 0, loc),
+			wantErr:  true,
+			wantCode: CodeTooSoon,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.Validate(now, tt.slot)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Validate(%v) error = nil, want error", tt.slot)
+				}
+				schedErr, ok := err.(*Error)
+				if !ok {
+					t.Fatalf("Validate(%v) error type = %T, want *Error", tt.slot, err)
+				}
+				if schedErr.Code != tt.wantCode {
+					t.Fatalf("Validate(%v) code = %q, want %q", tt.slot, schedErr.Code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Validate(%v) unexpected error: %v", tt.slot, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+var weekdayOrder = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// ParseWorkingHoursSpec parses a comma-separated list of day range/window
+// pairs such as "Mon-Fri:09:00-18:00,Sat:09:00-13:00" into a per-weekday
+// map. Weekdays not covered by any entry are left closed.
+func ParseWorkingHoursSpec(spec string) (map[time.Weekday]WorkingHours, error) {
+	hours := map[time.Weekday]WorkingHours{}
+	if strings.TrimSpace(spec) == "" {
+		return hours, nil
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid working hours entry %q: expected DAYS:START-END", entry)
+		}
+		days, window := parts[0], parts[1]
+
+		startEnd := strings.SplitN(window, "-", 2)
+		if len(startEnd) != 2 {
+			return nil, fmt.Errorf("invalid working hours window %q: expected START-END", window)
+		}
+		wh := WorkingHours{Start: startEnd[0], End: startEnd[1]}
+
+		weekdays, err := parseDayRange(days)
+		if err != nil {
+			return nil, fmt.Errorf("invalid working hours entry %q: %w", entry, err)
+		}
+		for _, d := range weekdays {
+			hours[d] = wh
+		}
+	}
+
+	return hours, nil
+}
+
+// parseDayRange expands a single day ("Mon") or range ("Mon-Fri") into its
+// weekdays.
+func parseDayRange(days string) ([]time.Weekday, error) {
+	if !strings.Contains(days, "-") {
+		d, ok := weekdayNames[days]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", days)
+		}
+		return []time.Weekday{d}, nil
+	}
+
+	bounds := strings.SplitN(days, "-", 2)
+	startIdx, err := weekdayIndex(bounds[0])
+	if err != nil {
+		return nil, err
+	}
+	endIdx, err := weekdayIndex(bounds[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var result []time.Weekday
+	for i := startIdx; ; i = (i + 1) % 7 {
+		result = append(result, weekdayNames[weekdayOrder[i]])
+		if i == endIdx {
+			break
+		}
+	}
+	return result, nil
+}
+
+func weekdayIndex(name string) (int, error) {
+	for i, n := range weekdayOrder {
+		if n == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", name)
+}
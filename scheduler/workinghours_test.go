@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseWorkingHoursSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[time.Weekday]WorkingHours
+		wantErr bool
+	}{
+		{
+			name: "simple range plus single day",
+			spec: "Mon-Fri:09:00-18:00,Sat:09:00-13:00",
+			want: map[time.Weekday]WorkingHours{
+				time.Monday:    {Start: "09:00", End: "18:00"},
+				time.Tuesday:   {Start: "09:00", End: "18:00"},
+				time.Wednesday: {Start: "09:00", End: "18:00"},
+				time.Thursday:  {Start: "09:00", End: "18:00"},
+				time.Friday:    {Start: "09:00", End: "18:00"},
+				time.Saturday:  {Start: "09:00", End: "13:00"},
+			},
+		},
+		{
+			name: "range wraps across the week boundary",
+			spec: "Fri-Mon:10:00-14:00",
+			want: map[time.Weekday]WorkingHours{
+				time.Friday:   {Start: "10:00", End: "14:00"},
+				time.Saturday: {Start: "10:00", End: "14:00"},
+				time.Sunday:   {Start: "10:00", End: "14:00"},
+				time.Monday:   {Start: "10:00", End: "14:00"},
+			},
+		},
+		{
+			name: "single-day range start equals end",
+			spec: "Wed-Wed:08:00-12:00",
+			want: map[time.Weekday]WorkingHours{
+				time.Wednesday: {Start: "08:00", End: "12:00"},
+			},
+		},
+		{
+			name: "empty spec means every day closed",
+			spec: "",
+			want: map[time.Weekday]WorkingHours{},
+		},
+		{
+			name:    "unknown weekday",
+			spec:    "Mon-Zzz:09:00-18:00",
+			wantErr: true,
+		},
+		{
+			name:    "missing window separator",
+			spec:    "Mon",
+			wantErr: true,
+		},
+		{
+			name:    "missing day/window separator",
+			spec:    "Mon-Fri",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWorkingHoursSpec(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseWorkingHoursSpec(%q) error = nil, want error", tt.spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWorkingHoursSpec(%q) unexpected error: %v", tt.spec, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("ParseWorkingHoursSpec(%q) = %#v, want %#v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Gautam3767/Sipper_Form_Backend/config"
+	"github.com/Gautam3767/Sipper_Form_Backend/scheduler"
+)
+
+// buildScheduler turns the scheduler section of the loaded config into a
+// scheduler.Scheduler.
+func buildScheduler(cfg config.Scheduler) (*scheduler.Scheduler, error) {
+	loc, err := time.LoadLocation(cfg.BusinessTZ)
+	if err != nil {
+		return nil, fmt.Errorf("loading business_tz %q: %w", cfg.BusinessTZ, err)
+	}
+
+	workingHours, err := scheduler.ParseWorkingHoursSpec(cfg.WorkingHours)
+	if err != nil {
+		return nil, fmt.Errorf("parsing working_hours: %w", err)
+	}
+
+	return scheduler.New(scheduler.Config{
+		Location:         loc,
+		MinLeadTime:      time.Duration(cfg.MinLeadHours) * time.Hour,
+		WorkingHours:     workingHours,
+		SlotDuration:     time.Duration(cfg.SlotMinutes) * time.Minute,
+		MaxOrdersPerSlot: cfg.MaxOrdersPerSlot,
+	}), nil
+}
+
+// writeSchedulerError renders a scheduler.Error as a structured 4xx JSON
+// body so clients can distinguish the failure mode programmatically.
+func writeSchedulerError(w http.ResponseWriter, err error) {
+	var schedErr *scheduler.Error
+	if !errors.As(err, &schedErr) {
+		http.Error(w, "Invalid delivery slot", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   string(schedErr.Code),
+		"message": schedErr.Message,
+	})
+}
@@ -0,0 +1,317 @@
+// Package webhooks dispatches signed order events to subscriber URLs, with
+// durable delivery state and retries driven by a background worker pool.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Status values a Delivery can be in.
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// deliveryWorkers bounds how many deliveries processDue attempts
+// concurrently, so one subscriber stalling up to the client's timeout
+// doesn't delay delivery to every other subscriber in the same poll tick.
+const deliveryWorkers = 5
+
+// backoffSchedule is how long to wait before each retry attempt, with a
+// small amount of jitter applied on top.
+var backoffSchedule = []time.Duration{
+	1 * time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+}
+
+// Subscriber is a registered webhook endpoint, stored in the "webhooks"
+// collection.
+type Subscriber struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	URL       string             `bson:"url" json:"url"`
+	Secret    string             `bson:"secret" json:"-"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// Delivery is one attempt (and retry history) to deliver an event to a
+// subscriber, stored in the "webhook_deliveries" collection.
+type Delivery struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	SubscriberID primitive.ObjectID `bson:"subscriberId" json:"subscriberId"`
+	EventID      string             `bson:"eventId" json:"eventId"`
+	EventType    string             `bson:"eventType" json:"eventType"`
+	Payload      []byte             `bson:"payload" json:"-"`
+	Status       string             `bson:"status" json:"status"`
+	Attempt      int                `bson:"attempt" json:"attempt"`
+	NextRetryAt  time.Time          `bson:"nextRetryAt" json:"nextRetryAt"`
+	LastError    string             `bson:"lastError,omitempty" json:"lastError,omitempty"`
+	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// event is the JSON body POSTed to subscribers.
+type event struct {
+	EventID   string      `json:"eventId"`
+	EventType string      `json:"eventType"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// Dispatcher registers subscribers and queues/delivers signed event POSTs.
+type Dispatcher struct {
+	subscribers *mongo.Collection
+	deliveries  *mongo.Collection
+	client      *http.Client
+}
+
+// New builds a Dispatcher backed by the given collections.
+func New(subscribers, deliveries *mongo.Collection) *Dispatcher {
+	return &Dispatcher{
+		subscribers: subscribers,
+		deliveries:  deliveries,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Dispatch fans an event out to every registered subscriber by inserting a
+// pending Delivery per subscriber. The background worker pool performs the
+// actual HTTP delivery and retries.
+func (d *Dispatcher) Dispatch(ctx context.Context, eventType, eventID string, data interface{}) error {
+	cur, err := d.subscribers.Find(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("listing webhook subscribers: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	var subs []Subscriber
+	if err := cur.All(ctx, &subs); err != nil {
+		return fmt.Errorf("decoding webhook subscribers: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event{
+		EventID:   eventID,
+		EventType: eventType,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload: %w", err)
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, 0, len(subs))
+	for _, sub := range subs {
+		docs = append(docs, Delivery{
+			SubscriberID: sub.ID,
+			EventID:      eventID,
+			EventType:    eventType,
+			Payload:      payload,
+			Status:       StatusPending,
+			NextRetryAt:  now,
+			CreatedAt:    now,
+		})
+	}
+
+	if _, err := d.deliveries.InsertMany(ctx, docs); err != nil {
+		return fmt.Errorf("queuing webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// RunWorker polls for due deliveries every pollInterval until ctx is
+// cancelled. It's meant to be started once as a background goroutine.
+func (d *Dispatcher) RunWorker(ctx context.Context, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.processDue(ctx)
+		}
+	}
+}
+
+// processDue attempts every delivery whose retry is due.
+func (d *Dispatcher) processDue(ctx context.Context) {
+	cur, err := d.deliveries.Find(ctx, bson.M{
+		"status":      StatusPending,
+		"nextRetryAt": bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		log.Printf("Error polling webhook deliveries: %v", err)
+		return
+	}
+	defer cur.Close(ctx)
+
+	var due []Delivery
+	if err := cur.All(ctx, &due); err != nil {
+		log.Printf("Error decoding webhook deliveries: %v", err)
+		return
+	}
+
+	jobs := make(chan Delivery)
+	var wg sync.WaitGroup
+	for i := 0; i < deliveryWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range jobs {
+				d.attempt(ctx, delivery)
+			}
+		}()
+	}
+	for _, delivery := range due {
+		jobs <- delivery
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// attempt performs a single delivery attempt and records its outcome,
+// scheduling a retry with backoff and jitter if it fails.
+func (d *Dispatcher) attempt(ctx context.Context, delivery Delivery) {
+	var sub Subscriber
+	if err := d.subscribers.FindOne(ctx, bson.M{"_id": delivery.SubscriberID}).Decode(&sub); err != nil {
+		d.markFailed(ctx, delivery, fmt.Sprintf("subscriber not found: %v", err))
+		return
+	}
+
+	if err := d.deliver(ctx, sub, delivery.Payload); err != nil {
+		d.scheduleRetry(ctx, delivery, err)
+		return
+	}
+
+	_, err := d.deliveries.UpdateOne(ctx,
+		bson.M{"_id": delivery.ID},
+		bson.M{"$set": bson.M{"status": StatusSucceeded, "attempt": delivery.Attempt + 1}},
+	)
+	if err != nil {
+		log.Printf("Error recording successful webhook delivery %s: %v", delivery.ID.Hex(), err)
+	}
+}
+
+// deliver POSTs payload to sub.URL, signed with sub.Secret.
+func (d *Dispatcher) deliver(ctx context.Context, sub Subscriber, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("building webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sipper-Signature", sign(sub.Secret, payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook endpoint returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// scheduleRetry records the failed attempt and schedules the next retry,
+// or marks the delivery permanently failed once the backoff schedule is
+// exhausted.
+func (d *Dispatcher) scheduleRetry(ctx context.Context, delivery Delivery, cause error) {
+	base, ok := nextRetryDelay(delivery.Attempt)
+	if !ok {
+		d.markFailed(ctx, delivery, cause.Error())
+		return
+	}
+
+	delay := base + time.Duration(rand.Int63n(int64(base)/4))
+
+	_, err := d.deliveries.UpdateOne(ctx,
+		bson.M{"_id": delivery.ID},
+		bson.M{"$set": bson.M{
+			"attempt":     delivery.Attempt + 1,
+			"nextRetryAt": time.Now().Add(delay),
+			"lastError":   cause.Error(),
+		}},
+	)
+	if err != nil {
+		log.Printf("Error scheduling webhook retry %s: %v", delivery.ID.Hex(), err)
+	}
+}
+
+// nextRetryDelay returns the base backoff delay for a delivery that has
+// failed attempt times so far (0 for the first failure), and false once
+// the backoff schedule is exhausted and the delivery should be given up on.
+// Jitter is applied by the caller, since it isn't deterministic.
+func nextRetryDelay(attempt int) (delay time.Duration, ok bool) {
+	if attempt < 0 || attempt >= len(backoffSchedule) {
+		return 0, false
+	}
+	return backoffSchedule[attempt], true
+}
+
+// markFailed records a delivery as permanently failed.
+func (d *Dispatcher) markFailed(ctx context.Context, delivery Delivery, cause string) {
+	_, err := d.deliveries.UpdateOne(ctx,
+		bson.M{"_id": delivery.ID},
+		bson.M{"$set": bson.M{
+			"status":    StatusFailed,
+			"attempt":   delivery.Attempt + 1,
+			"lastError": cause,
+		}},
+	)
+	if err != nil {
+		log.Printf("Error marking webhook delivery %s failed: %v", delivery.ID.Hex(), err)
+	}
+}
+
+// Replay resets a delivery back to pending so the worker pool retries it
+// immediately, regardless of its current status or backoff.
+func (d *Dispatcher) Replay(ctx context.Context, deliveryID primitive.ObjectID) error {
+	res, err := d.deliveries.UpdateOne(ctx,
+		bson.M{"_id": deliveryID},
+		bson.M{"$set": bson.M{
+			"status":      StatusPending,
+			"nextRetryAt": time.Now(),
+			"attempt":     0,
+			"lastError":   "",
+		}},
+	)
+	if err != nil {
+		return fmt.Errorf("replaying webhook delivery: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("webhook delivery not found")
+	}
+	return nil
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of payload using secret.
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
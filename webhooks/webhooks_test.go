@@ -0,0 +1,36 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRetryDelay(t *testing.T) {
+	tests := []struct {
+		name      string
+		attempt   int
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{"first retry", 0, 1 * time.Second, true},
+		{"second retry", 1, 5 * time.Second, true},
+		{"third retry", 2, 30 * time.Second, true},
+		{"fourth retry", 3, 2 * time.Minute, true},
+		{"fifth retry", 4, 10 * time.Minute, true},
+		{"schedule exhausted", 5, 0, false},
+		{"past exhausted", 10, 0, false},
+		{"negative attempt", -1, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := nextRetryDelay(tt.attempt)
+			if ok != tt.wantOK {
+				t.Fatalf("nextRetryDelay(%d) ok = %v, want %v", tt.attempt, ok, tt.wantOK)
+			}
+			if ok && delay != tt.wantDelay {
+				t.Fatalf("nextRetryDelay(%d) delay = %v, want %v", tt.attempt, delay, tt.wantDelay)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Gautam3767/Sipper_Form_Backend/webhooks"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// dispatchOrderCreated fans the "order.created" event out to every
+// registered webhook subscriber. It runs in its own goroutine so slow or
+// unreachable subscribers never delay the HTTP response.
+func dispatchOrderCreated(o Order) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := webhookDispatcher.Dispatch(ctx, "order.created", o.ID.Hex(), o); err != nil {
+		log.Printf("Error dispatching order.created webhook for order %s: %v", o.ID.Hex(), err)
+	}
+}
+
+// webhookSubscribersHandler lists or registers webhook subscribers.
+func webhookSubscribersHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		listWebhookSubscribers(w, r)
+	case http.MethodPost:
+		registerWebhookSubscriber(w, r)
+	default:
+		http.Error(w, "Only GET and POST are allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func listWebhookSubscribers(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := webhookSubscribersColl.Find(ctx, bson.M{})
+	if err != nil {
+		log.Printf("Error listing webhook subscribers: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer cur.Close(ctx)
+
+	var subs []webhooks.Subscriber
+	if err := cur.All(ctx, &subs); err != nil {
+		log.Printf("Error decoding webhook subscribers: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(subs)
+}
+
+func registerWebhookSubscriber(w http.ResponseWriter, r *http.Request) {
+	var sub webhooks.Subscriber
+	if err := json.NewDecoder(r.Body).Decode(&sub); err != nil {
+		http.Error(w, "Invalid JSON data", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	if sub.URL == "" || sub.Secret == "" {
+		http.Error(w, "url and secret are required", http.StatusBadRequest)
+		return
+	}
+	sub.CreatedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	res, err := webhookSubscribersColl.InsertOne(ctx, sub)
+	if err != nil {
+		log.Printf("Error registering webhook subscriber: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"id": res.InsertedID})
+}
+
+// webhookDeliveriesHandler lists webhook deliveries, optionally filtered by
+// ?status=.
+func webhookDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Only GET is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filter := bson.M{}
+	if status := r.URL.Query().Get("status"); status != "" {
+		filter["status"] = status
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cur, err := webhookDeliveriesColl.Find(ctx, filter)
+	if err != nil {
+		log.Printf("Error listing webhook deliveries: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer cur.Close(ctx)
+
+	var deliveries []webhooks.Delivery
+	if err := cur.All(ctx, &deliveries); err != nil {
+		log.Printf("Error decoding webhook deliveries: %v", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+// webhookReplayHandler replays a failed delivery at
+// /webhooks/deliveries/{id}/replay.
+func webhookReplayHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/webhooks/deliveries/"), "/replay")
+	deliveryID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		http.Error(w, "Invalid delivery id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := webhookDispatcher.Replay(ctx, deliveryID); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}